@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/lint/cache"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+func newCacheCommand(log logutils.Log) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Cache control and information",
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "clean",
+			Short: "Clean cache",
+			Run: func(cmd *cobra.Command, args []string) {
+				executeCacheClean(log)
+			},
+		},
+		&cobra.Command{
+			Use:   "status",
+			Short: "Show cache status",
+			Run: func(cmd *cobra.Command, args []string) {
+				executeCacheStatus(log)
+			},
+		},
+	)
+
+	return cmd
+}
+
+func executeCacheClean(log logutils.Log) {
+	c, err := cache.NewCache(log)
+	if err != nil {
+		log.Fatalf("Failed to build cache: %s", err)
+	}
+
+	if err := c.Clean(); err != nil {
+		log.Fatalf("Failed to clean cache: %s", err)
+	}
+}
+
+func executeCacheStatus(log logutils.Log) {
+	c, err := cache.NewCache(log)
+	if err != nil {
+		log.Fatalf("Failed to build cache: %s", err)
+	}
+
+	st, err := c.Status()
+	if err != nil {
+		log.Fatalf("Failed to read cache status: %s", err)
+	}
+
+	fmt.Printf("Dir: %s\n", st.Dir)
+	fmt.Printf("Entries: %d\n", st.Entries)
+	fmt.Printf("Size: %d bytes\n", st.SizeBytes)
+}
@@ -0,0 +1,169 @@
+// Package cache implements a content-addressed, on-disk cache of linter
+// results so that repeated runs only re-execute linters whose inputs
+// actually changed.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const cacheFormatVersion = 1
+
+const (
+	cacheDirPerm  = 0o750
+	cacheFilePerm = 0o644
+)
+
+// Key identifies a single (linter, package set) cache entry. Two runs that
+// produce an equal Key are guaranteed to produce the same issues, so the
+// second run can reuse the first run's output.
+type Key struct {
+	LinterName       string
+	LinterVersion    string
+	Settings         string // JSON-marshaled linter settings
+	GoBuildInfo      string
+	Package          string   // non-empty for per-package linter sub-tasks (e.g. gosec, unused)
+	FileHashes       []string // content hashes of the package's own files
+	ExportDataHashes []string // content hashes of imported packages' export data
+}
+
+// Hash returns a stable digest of the key suitable for use as a file name.
+func (k Key) Hash() string {
+	files := append([]string{}, k.FileHashes...)
+	sort.Strings(files)
+	exports := append([]string{}, k.ExportDataHashes...)
+	sort.Strings(exports)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "v%d\n%s\n%s\n%s\n%s\n%s\n",
+		cacheFormatVersion, k.LinterName, k.LinterVersion, k.Settings, k.GoBuildInfo, k.Package)
+
+	// Each section is prefixed with its length so that, e.g., moving an
+	// element from FileHashes to ExportDataHashes changes the hash instead
+	// of producing two different keys that happen to serialize the same way.
+	fmt.Fprintf(h, "%d\n", len(files))
+	for _, f := range files {
+		fmt.Fprintln(h, f)
+	}
+	fmt.Fprintf(h, "%d\n", len(exports))
+	for _, e := range exports {
+		fmt.Fprintln(h, e)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Cache is an on-disk store of []result.Issue, keyed by Key.Hash().
+type Cache struct {
+	dir string
+	log logutils.Log
+}
+
+// NewCache creates a cache rooted at $XDG_CACHE_HOME/golangci-lint (falling
+// back to $HOME/.cache/golangci-lint), creating the directory if needed.
+func NewCache(log logutils.Log) (*Cache, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine cache dir: %s", err)
+	}
+
+	if err := os.MkdirAll(dir, cacheDirPerm); err != nil {
+		return nil, fmt.Errorf("failed to create cache dir %s: %s", dir, err)
+	}
+
+	return &Cache{dir: dir, log: log}, nil
+}
+
+func cacheDir() (string, error) {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "golangci-lint"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".cache", "golangci-lint"), nil
+}
+
+func (c *Cache) path(key Key) string {
+	return filepath.Join(c.dir, key.Hash()+".json")
+}
+
+// Get returns the cached issues for key, if present.
+func (c *Cache) Get(key Key) ([]result.Issue, bool) {
+	data, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var issues []result.Issue
+	if err := json.Unmarshal(data, &issues); err != nil {
+		c.log.Warnf("Cache: failed to unmarshal entry for %s: %s", key.LinterName, err)
+		return nil, false
+	}
+
+	return issues, true
+}
+
+// Put stores issues under key, overwriting any previous entry.
+func (c *Cache) Put(key Key, issues []result.Issue) error {
+	data, err := json.Marshal(issues)
+	if err != nil {
+		return fmt.Errorf("failed to marshal issues: %s", err)
+	}
+
+	return ioutil.WriteFile(c.path(key), data, cacheFilePerm)
+}
+
+// Clean removes all entries from the cache.
+func (c *Cache) Clean() error {
+	return os.RemoveAll(c.dir)
+}
+
+// Dir returns the root directory backing the cache.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Status reports the number of cached entries and their total size on disk.
+type Status struct {
+	Dir       string
+	Entries   int
+	SizeBytes int64
+}
+
+func (c *Cache) Status() (Status, error) {
+	st := Status{Dir: c.dir}
+
+	entries, err := ioutil.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return st, nil
+		}
+		return st, err
+	}
+
+	for _, e := range entries {
+		// Only entry files (Key.Hash()+".json") count; other files kept in
+		// the cache dir, such as the scheduler's stats, are skipped.
+		if e.IsDir() || len(e.Name()) != hex.EncodedLen(sha256.Size)+len(".json") {
+			continue
+		}
+		st.Entries++
+		st.SizeBytes += e.Size()
+	}
+
+	return st, nil
+}
@@ -0,0 +1,63 @@
+package cache
+
+import "testing"
+
+func TestKeyHashStableForEqualKeys(t *testing.T) {
+	k1 := Key{
+		LinterName:       "govet",
+		LinterVersion:    "1.0",
+		Settings:         `{"a":1}`,
+		GoBuildInfo:      "go1.20",
+		FileHashes:       []string{"b", "a"},
+		ExportDataHashes: []string{"y", "x"},
+	}
+	k2 := Key{
+		LinterName:       "govet",
+		LinterVersion:    "1.0",
+		Settings:         `{"a":1}`,
+		GoBuildInfo:      "go1.20",
+		FileHashes:       []string{"a", "b"}, // different order, same set
+		ExportDataHashes: []string{"x", "y"},
+	}
+
+	if k1.Hash() != k2.Hash() {
+		t.Fatalf("expected equal keys (modulo file order) to hash the same: %s != %s", k1.Hash(), k2.Hash())
+	}
+}
+
+func TestKeyHashDiffersOnAnyField(t *testing.T) {
+	base := Key{LinterName: "govet", LinterVersion: "1.0", Settings: "{}", GoBuildInfo: "go1.20", FileHashes: []string{"a"}}
+
+	variants := []Key{
+		{LinterName: "staticcheck", LinterVersion: "1.0", Settings: "{}", GoBuildInfo: "go1.20", FileHashes: []string{"a"}},
+		{LinterName: "govet", LinterVersion: "2.0", Settings: "{}", GoBuildInfo: "go1.20", FileHashes: []string{"a"}},
+		{LinterName: "govet", LinterVersion: "1.0", Settings: `{"x":1}`, GoBuildInfo: "go1.20", FileHashes: []string{"a"}},
+		{LinterName: "govet", LinterVersion: "1.0", Settings: "{}", GoBuildInfo: "go1.21", FileHashes: []string{"a"}},
+		{LinterName: "govet", LinterVersion: "1.0", Settings: "{}", GoBuildInfo: "go1.20", FileHashes: []string{"b"}},
+		{LinterName: "govet", LinterVersion: "1.0", Settings: "{}", GoBuildInfo: "go1.20", FileHashes: []string{"a"}, Package: "pkg/foo"},
+	}
+
+	baseHash := base.Hash()
+	for i, v := range variants {
+		if v.Hash() == baseHash {
+			t.Errorf("variant %d unexpectedly hashed the same as base", i)
+		}
+	}
+}
+
+func TestKeyHashDiffersWhenElementMovesBetweenHashLists(t *testing.T) {
+	a := Key{LinterName: "govet", FileHashes: []string{"a", "b"}, ExportDataHashes: []string{}}
+	b := Key{LinterName: "govet", FileHashes: []string{"a"}, ExportDataHashes: []string{"b"}}
+
+	if a.Hash() == b.Hash() {
+		t.Fatal("moving a hash from FileHashes to ExportDataHashes must change Key.Hash()")
+	}
+}
+
+func TestCacheGetMissReturnsFalse(t *testing.T) {
+	c := &Cache{dir: t.TempDir()}
+
+	if _, ok := c.Get(Key{LinterName: "unknown"}); ok {
+		t.Fatal("expected cache miss for unseeded key")
+	}
+}
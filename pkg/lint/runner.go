@@ -2,7 +2,10 @@ package lint
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"runtime/debug"
 	"sort"
 	"strings"
@@ -11,16 +14,49 @@ import (
 
 	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/lint/astcache"
+	"github.com/golangci/golangci-lint/pkg/lint/cache"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/lint/scheduler"
+	"github.com/golangci/golangci-lint/pkg/lint/telemetry"
+	"github.com/golangci/golangci-lint/pkg/lint/workerpool"
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/result"
 	"github.com/golangci/golangci-lint/pkg/result/processors"
 	"github.com/golangci/golangci-lint/pkg/timeutils"
 )
 
+const schedulerStatsFile = "scheduler-stats.json"
+
+// perPackageLinter is implemented by linters that have their own native
+// per-package entry point. It's an optional fast path: linters in
+// perPackageLinterNames that don't implement it are still split, just by
+// running their regular Run method against a lintCtx scoped to one package
+// (see runLinterSafe).
+type perPackageLinter interface {
+	RunOnPackage(ctx context.Context, lintCtx *linter.Context, pkg string) ([]result.Issue, error)
+}
+
+// perPackageLinterNames lists the linters whose analysis cost scales with
+// the number of packages (gosec, unused), so splitting them into
+// per-package sub-tasks and spreading those across workers cuts tail idle
+// time instead of tying up a single worker for the whole run.
+var perPackageLinterNames = map[string]bool{
+	"gosec":  true,
+	"unused": true,
+}
+
 type Runner struct {
 	Processors []processors.Processor
 	Log        logutils.Log
+
+	// Cache is the on-disk linter result cache. It's nil when the user
+	// passed --no-cache or caching is otherwise disabled.
+	Cache *cache.Cache
+
+	// WorkerInfo tracks every currently in-flight linter invocation, so a
+	// hung or panicking run can be diagnosed (--debug workers, SIGUSR1,
+	// --debug-addr).
+	WorkerInfo *telemetry.Registry
 }
 
 func NewRunner(astCache *astcache.Cache, cfg *config.Config, log logutils.Log) (*Runner, error) {
@@ -40,7 +76,17 @@ func NewRunner(astCache *astcache.Cache, cfg *config.Config, log logutils.Log) (
 		return nil, err
 	}
 
+	var resultCache *cache.Cache
+	if !cfg.Run.NoCache {
+		resultCache, err = cache.NewCache(log.Child("cache"))
+		if err != nil {
+			log.Warnf("Disabling result cache: %s", err)
+		}
+	}
+
 	return &Runner{
+		Cache:      resultCache,
+		WorkerInfo: telemetry.NewRegistry(),
 		Processors: []processors.Processor{
 			processors.NewPathPrettifier(), // must be before diff, nolint and exclude autogenerated processor at least
 			processors.NewCgo(),
@@ -48,6 +94,11 @@ func NewRunner(astCache *astcache.Cache, cfg *config.Config, log logutils.Log) (
 
 			processors.NewAutogeneratedExclude(astCache),
 			processors.NewExclude(excludeTotalPattern),
+			// NewNolint must tag matched issues as Suppressed (and set
+			// SuppressComment) rather than dropping them, and NewUniqByLine
+			// and NewDiff below must carry RuleID/Severity/Suppressed
+			// through when they dedupe/filter, for pkg/printers.SARIF's
+			// suppressions output to ever see a real issue.
 			processors.NewNolint(astCache),
 
 			processors.NewUniqByLine(),
@@ -66,81 +117,117 @@ type lintRes struct {
 	issues []result.Issue
 }
 
+func (r Runner) cacheKey(lintCtx *linter.Context, lc linter.Config, pkg string) cache.Key {
+	settings, _ := json.Marshal(lc.LinterSettings())
+
+	// A per-package sub-task's result only depends on that package's own
+	// files and its imports' export data, not the whole module's - scope
+	// the hashes to pkg so one file changing doesn't invalidate every
+	// other package's cache entry too.
+	fileHashes := lintCtx.PackageFileHashes()
+	exportDataHashes := lintCtx.ExportDataHashes()
+	if pkg != "" {
+		fileHashes = lintCtx.PackageFileHashesFor(pkg)
+		exportDataHashes = lintCtx.ExportDataHashesFor(pkg)
+	}
+
+	return cache.Key{
+		LinterName:       lc.Linter.Name(),
+		LinterVersion:    lc.Linter.Desc(),
+		Settings:         string(settings),
+		GoBuildInfo:      lintCtx.BuildInfo(),
+		Package:          pkg,
+		FileHashes:       fileHashes,
+		ExportDataHashes: exportDataHashes,
+	}
+}
+
+// runLinterSafe runs lc, recovering from panics and going through the
+// result cache and WorkerInfo telemetry. When pkg is non-empty, only that
+// package is analyzed: via lc.Linter's native RunOnPackage if it implements
+// perPackageLinter, otherwise by scoping a copy of lintCtx down to pkg and
+// calling the regular Run - this is how the work-stealing scheduler's
+// per-package sub-tasks (e.g. gosec, unused) stay covered by the same
+// panic/cache/telemetry machinery as a normal whole-run invocation.
 func (r Runner) runLinterSafe(ctx context.Context, lintCtx *linter.Context,
-	lc linter.Config) (ret []result.Issue, err error) {
+	lc linter.Config, pkg string) (ret []result.Issue, err error) {
+
+	telemetryPkg := pkg
+	if telemetryPkg == "" {
+		telemetryPkg = lintCtx.PackageName()
+	}
+	handle := r.WorkerInfo.Start(lc.Linter.Name(), telemetryPkg)
+	defer handle.Done()
 
 	defer func() {
 		if panicData := recover(); panicData != nil {
+			info := handle.Snapshot()
 			err = fmt.Errorf("panic occurred: %s", panicData)
-			r.Log.Warnf("Panic stack trace: %s", debug.Stack())
+			r.Log.Warnf("Panic stack trace (linter=%s package=%s stage=%s running=%s): %s",
+				info.LinterName, info.Package, info.Stage, time.Since(info.StartedAt), debug.Stack())
 		}
 	}()
 
+	var key cache.Key
+	if r.Cache != nil {
+		key = r.cacheKey(lintCtx, lc, pkg)
+		if issues, ok := r.Cache.Get(key); ok {
+			r.Log.Infof("Cache hit for %s, skipping run", lc.Linter.Name())
+			return issues, nil
+		}
+	}
+
+	handle.SetStage(telemetry.StageAnalyzing)
+
 	specificLintCtx := *lintCtx
 	specificLintCtx.Log = r.Log.Child(lc.Linter.Name())
-	issues, err := lc.Linter.Run(ctx, &specificLintCtx)
+
+	var issues []result.Issue
+	if pkg != "" {
+		if ppl, ok := lc.Linter.(perPackageLinter); ok {
+			issues, err = ppl.RunOnPackage(ctx, &specificLintCtx, pkg)
+		} else {
+			specificLintCtx.Packages = []string{pkg}
+			issues, err = lc.Linter.Run(ctx, &specificLintCtx)
+		}
+	} else {
+		issues, err = lc.Linter.Run(ctx, &specificLintCtx)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	handle.SetStage(telemetry.StagePostProcessing)
+
 	for _, i := range issues {
 		i.FromLinter = lc.Linter.Name()
 	}
 
-	return issues, nil
-}
-
-func (r Runner) runWorker(ctx context.Context, lintCtx *linter.Context,
-	tasksCh <-chan linter.Config, lintResultsCh chan<- lintRes, name string) {
-
-	sw := timeutils.NewStopwatch(name, r.Log)
-	defer sw.Print()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case lc, ok := <-tasksCh:
-			if !ok {
-				return
-			}
-			if ctx.Err() != nil {
-				// XXX: if check it in only int a select
-				// it's possible to not enter to this case until tasksCh is empty.
-				return
-			}
-			var issues []result.Issue
-			var err error
-			sw.TrackStage(lc.Linter.Name(), func() {
-				issues, err = r.runLinterSafe(ctx, lintCtx, lc)
-			})
-			lintResultsCh <- lintRes{
-				linter: lc,
-				err:    err,
-				issues: issues,
-			}
+	if r.Cache != nil {
+		if err := r.Cache.Put(key, issues); err != nil {
+			r.Log.Warnf("Failed to write cache entry for %s: %s", lc.Linter.Name(), err)
 		}
 	}
+
+	return issues, nil
 }
 
-func (r Runner) logWorkersStat(workersFinishTimes []time.Time) {
-	lastFinishTime := workersFinishTimes[0]
-	for _, t := range workersFinishTimes {
-		if t.After(lastFinishTime) {
-			lastFinishTime = t
-		}
+// linterDeadline resolves the per-linter timeout: run.linter-deadline if
+// set, otherwise the global run deadline so a single slow linter can't
+// silently exceed it.
+func (r Runner) linterDeadline(lintCtx *linter.Context) time.Duration {
+	if d := lintCtx.Cfg.Run.LinterDeadline; d > 0 {
+		return d
 	}
 
-	logStrings := []string{}
-	for i, t := range workersFinishTimes {
-		if t.Equal(lastFinishTime) {
-			continue
-		}
+	return lintCtx.Cfg.Run.Deadline
+}
 
-		logStrings = append(logStrings, fmt.Sprintf("#%d: %s", i+1, lastFinishTime.Sub(t)))
+func newDeadlineIssue(lc linter.Config, deadline time.Duration) result.Issue {
+	return result.Issue{
+		FromLinter: lc.Linter.Name(),
+		Text:       fmt.Sprintf("linter %s exceeded deadline (%s) and was cancelled", lc.Linter.Name(), deadline),
 	}
-
-	r.Log.Infof("Workers idle times: %s", strings.Join(logStrings, ", "))
 }
 
 func getSortedLintersConfigs(linters []linter.Config) []linter.Config {
@@ -155,33 +242,173 @@ func getSortedLintersConfigs(linters []linter.Config) []linter.Config {
 }
 
 func (r *Runner) runWorkers(ctx context.Context, lintCtx *linter.Context, linters []linter.Config) <-chan lintRes {
-	tasksCh := make(chan linter.Config, len(linters))
+	if lintCtx.Cfg.Run.Scheduler == config.SchedulerWorkStealing {
+		return r.runWorkersStealing(ctx, lintCtx, linters)
+	}
+
+	return r.runWorkersStatic(ctx, lintCtx, linters)
+}
+
+// runWorkersStatic dispatches one linter per task through a workerpool.AsyncPool,
+// sized to lintCtx.Cfg.Run.Concurrency. Each task gets its own deadline
+// derived from linterDeadline, so one slow linter can no longer stall the
+// whole run: on timeout the task is cancelled and a synthetic issue is
+// emitted instead of the linter's real results.
+func (r *Runner) runWorkersStatic(ctx context.Context, lintCtx *linter.Context, linters []linter.Config) <-chan lintRes {
 	lintResultsCh := make(chan lintRes, len(linters))
-	var wg sync.WaitGroup
 
-	workersFinishTimes := make([]time.Time, lintCtx.Cfg.Run.Concurrency)
+	deadline := r.linterDeadline(lintCtx)
+	pool := workerpool.New(lintCtx.Cfg.Run.Concurrency, func() (context.Context, context.CancelFunc) {
+		if deadline <= 0 {
+			return context.WithCancel(ctx)
+		}
+		return context.WithTimeout(ctx, deadline)
+	})
 
-	for i := 0; i < lintCtx.Cfg.Run.Concurrency; i++ {
-		wg.Add(1)
-		go func(i int) {
-			defer wg.Done()
-			name := fmt.Sprintf("worker.%d", i+1)
-			r.runWorker(ctx, lintCtx, tasksCh, lintResultsCh, name)
-			workersFinishTimes[i] = time.Now()
-		}(i)
+	type taskResult struct {
+		lc     linter.Config
+		issues []result.Issue
 	}
 
+	var pending sync.WaitGroup
+	pool.OnComplete(func(res workerpool.Result) {
+		defer pending.Done()
+
+		tr := res.Value.(taskResult) //nolint:errcheck
+
+		if errors.Is(res.Err, context.DeadlineExceeded) {
+			lintResultsCh <- lintRes{linter: tr.lc, issues: []result.Issue{newDeadlineIssue(tr.lc, deadline)}}
+			return
+		}
+
+		lintResultsCh <- lintRes{linter: tr.lc, err: res.Err, issues: tr.issues}
+	})
+
 	lcs := getSortedLintersConfigs(linters)
 	for _, lc := range lcs {
-		tasksCh <- lc
+		lc := lc
+		pending.Add(1)
+		pool.Submit(workerpool.Task{
+			Key: lc.Linter.Name(),
+			Run: func(taskCtx context.Context) (interface{}, error) {
+				issues, err := r.runLinterSafe(taskCtx, lintCtx, lc, "")
+				return taskResult{lc: lc, issues: issues}, err
+			},
+		})
+	}
+
+	go func() {
+		pending.Wait()
+		_ = pool.Shutdown(context.Background())
+		close(lintResultsCh)
+	}()
+
+	return lintResultsCh
+}
+
+// buildStealingTasks turns linters into scheduler tasks, splitting linters
+// named in perPackageLinterNames (or implementing perPackageLinter) into one
+// task per package so they can be spread across workers instead of
+// monopolizing a single one. Every task - whole-run or per-package - goes
+// through runLinterSafe, so panic recovery, the result cache and
+// WorkerInfo telemetry cover these tasks
+// exactly like any other linter invocation, and ctx is honored: cancelling
+// ctx (deadline, Ctrl-C) cancels whichever task is in flight. Each task also
+// gets its own linterDeadline timeout, same as runWorkersStatic, so a single
+// slow linter can't stall its worker indefinitely in work-stealing mode
+// either; on timeout a synthetic "exceeded deadline" issue is reported in
+// place of the linter's real results.
+func (r *Runner) buildStealingTasks(ctx context.Context, lintCtx *linter.Context, linters []linter.Config,
+	resultsCh chan<- lintRes, stats *scheduler.Stats) []scheduler.Task {
+
+	var tasks []scheduler.Task
+
+	deadline := r.linterDeadline(lintCtx)
+
+	runAndReport := func(lc linter.Config, pkg string) func() {
+		return func() {
+			taskCtx := ctx
+			cancel := func() {}
+			if deadline > 0 {
+				taskCtx, cancel = context.WithTimeout(ctx, deadline)
+			}
+			defer cancel()
+
+			start := time.Now()
+			issues, err := r.runLinterSafe(taskCtx, lintCtx, lc, pkg)
+			t := scheduler.Task{LinterName: lc.Linter.Name(), Package: pkg}
+			stats.Record(t, time.Since(start))
+
+			if errors.Is(err, context.DeadlineExceeded) {
+				resultsCh <- lintRes{linter: lc, issues: []result.Issue{newDeadlineIssue(lc, deadline)}}
+				return
+			}
+
+			resultsCh <- lintRes{linter: lc, err: err, issues: issues}
+		}
+	}
+
+	for _, lc := range linters {
+		lc := lc
+		_, nativePerPackage := lc.Linter.(perPackageLinter)
+		if (nativePerPackage || perPackageLinterNames[lc.Linter.Name()]) && len(lintCtx.Packages) > 0 {
+			for _, pkg := range lintCtx.Packages {
+				tasks = append(tasks, scheduler.Task{
+					LinterName: lc.Linter.Name(),
+					Package:    pkg,
+					Run:        runAndReport(lc, pkg),
+				})
+			}
+			continue
+		}
+
+		tasks = append(tasks, scheduler.Task{
+			LinterName: lc.Linter.Name(),
+			Run:        runAndReport(lc, ""),
+		})
+	}
+
+	return tasks
+}
+
+func (r *Runner) runWorkersStealing(ctx context.Context, lintCtx *linter.Context, linters []linter.Config) <-chan lintRes {
+	lintResultsCh := make(chan lintRes, len(linters))
+
+	statsPath := schedulerStatsFile
+	if r.Cache != nil {
+		statsPath = r.Cache.Dir() + "/" + schedulerStatsFile
+	}
+	stats := scheduler.LoadStats(statsPath)
+
+	tasks := r.buildStealingTasks(ctx, lintCtx, linters, lintResultsCh, stats)
+	concurrency := lintCtx.Cfg.Run.Concurrency
+	sched := scheduler.New(tasks, concurrency, stats)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for {
+				if ctx.Err() != nil {
+					return
+				}
+				task, ok := sched.Next(i)
+				if !ok {
+					return
+				}
+				task.Run()
+			}
+		}(i)
 	}
-	close(tasksCh)
 
 	go func() {
 		wg.Wait()
 		close(lintResultsCh)
 
-		r.logWorkersStat(workersFinishTimes)
+		if err := stats.Save(); err != nil {
+			r.Log.Warnf("Failed to persist scheduler stats: %s", err)
+		}
 	}()
 
 	return lintResultsCh
@@ -241,6 +468,18 @@ func collectIssues(ctx context.Context, resCh <-chan lintRes) <-chan result.Issu
 }
 
 func (r Runner) Run(ctx context.Context, linters []linter.Config, lintCtx *linter.Context) <-chan result.Issue {
+	stopSignalWatch := telemetry.WatchSignals(r.WorkerInfo)
+
+	var srv *http.Server
+	if addr := lintCtx.Cfg.Run.DebugAddr; addr != "" {
+		srv = &http.Server{Addr: addr, Handler: telemetry.Handler(r.WorkerInfo)}
+		go func() {
+			if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				r.Log.Warnf("Debug HTTP server on %s stopped: %s", addr, err)
+			}
+		}()
+	}
+
 	lintResultsCh := r.runWorkers(ctx, lintCtx, linters)
 	processedLintResultsCh := r.processLintResults(ctx, lintResultsCh)
 	if ctx.Err() != nil {
@@ -254,7 +493,26 @@ func (r Runner) Run(ctx context.Context, linters []linter.Config, lintCtx *linte
 			finishedLintersN, len(linters))
 	}
 
-	return collectIssues(ctx, processedLintResultsCh)
+	issuesCh := collectIssues(ctx, processedLintResultsCh)
+
+	// The actual linting happens after Run returns: everything above just
+	// wires up goroutines feeding issuesCh. Tearing down the SIGUSR1 watch
+	// and --debug-addr server via defer here would kill both before a
+	// single linter started, so instead tie teardown to issuesCh draining.
+	outCh := make(chan result.Issue, 1024)
+	go func() {
+		defer close(outCh)
+		defer stopSignalWatch()
+		if srv != nil {
+			defer srv.Close() //nolint:errcheck
+		}
+
+		for i := range issuesCh {
+			outCh <- i
+		}
+	}()
+
+	return outCh
 }
 
 func (r *Runner) processIssues(ctx context.Context, issues []result.Issue, sw *timeutils.Stopwatch) []result.Issue {
@@ -0,0 +1,195 @@
+// Package scheduler implements a work-stealing task scheduler for the lint
+// Runner. Each worker owns a local deque of tasks, seeded by a
+// longest-processing-time-first (LPT) bin packing over historical
+// per-linter/per-package durations; idle workers steal from the back of
+// other workers' deques once their own deque is empty.
+package scheduler
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Task is a single unit of schedulable work: running one linter, optionally
+// restricted to one package for linters that support per-package execution.
+type Task struct {
+	LinterName string
+	Package    string // empty means "the whole run"
+	Run        func()
+}
+
+// key identifies a Task for duration lookups and persisted stats.
+func (t Task) key() string {
+	if t.Package == "" {
+		return t.LinterName
+	}
+	return t.LinterName + "@" + t.Package
+}
+
+// Stats holds historical wall-clock durations, keyed by Task.key(), used to
+// seed the next run's bin packing. It's persisted as a small JSON file at
+// the path given to LoadStats (the Runner uses the cache dir, or the working
+// directory when the result cache is disabled) so packing quality improves
+// across runs.
+type Stats struct {
+	path      string
+	mu        sync.Mutex
+	Durations map[string]time.Duration `json:"durations"`
+}
+
+// LoadStats reads persisted stats from path, returning empty stats if the
+// file doesn't exist yet or can't be parsed.
+func LoadStats(path string) *Stats {
+	st := &Stats{path: path, Durations: map[string]time.Duration{}}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return st
+	}
+
+	_ = json.Unmarshal(data, st)
+	if st.Durations == nil {
+		st.Durations = map[string]time.Duration{}
+	}
+
+	return st
+}
+
+// Record stores the observed duration of a task for future packing.
+func (s *Stats) Record(t Task, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Durations[t.key()] = d
+}
+
+// Save persists the stats to disk.
+func (s *Stats) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o750); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(s.path, data, 0o644)
+}
+
+func (s *Stats) estimate(t Task) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d, ok := s.Durations[t.key()]; ok {
+		return d
+	}
+
+	// Unknown tasks get a fixed one-second estimate so they don't get
+	// dumped entirely onto one worker.
+	return time.Second
+}
+
+// deque is a worker-local double-ended queue of tasks. Owners pop/push from
+// the front; thieves steal from the back.
+type deque struct {
+	mu    sync.Mutex
+	tasks []Task
+}
+
+func (d *deque) pushFront(t Task) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.tasks = append([]Task{t}, d.tasks...)
+}
+
+func (d *deque) popFront() (Task, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.tasks) == 0 {
+		return Task{}, false
+	}
+	t := d.tasks[0]
+	d.tasks = d.tasks[1:]
+	return t, true
+}
+
+func (d *deque) stealBack() (Task, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n := len(d.tasks)
+	if n == 0 {
+		return Task{}, false
+	}
+	t := d.tasks[n-1]
+	d.tasks = d.tasks[:n-1]
+	return t, true
+}
+
+// Scheduler packs tasks into per-worker deques and lets idle workers steal
+// from one another once their own deque runs dry.
+type Scheduler struct {
+	stats   *Stats
+	workers []*deque
+}
+
+// New builds a Scheduler for numWorkers, bin-packing tasks across them using
+// longest-processing-time-first based on stats.
+func New(tasks []Task, numWorkers int, stats *Stats) *Scheduler {
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+
+	sorted := append([]Task{}, tasks...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return stats.estimate(sorted[i]) > stats.estimate(sorted[j])
+	})
+
+	workers := make([]*deque, numWorkers)
+	loads := make([]time.Duration, numWorkers)
+	for i := range workers {
+		workers[i] = &deque{}
+	}
+
+	for _, t := range sorted {
+		// Assign each task to the currently least-loaded worker (greedy LPT).
+		min := 0
+		for i := 1; i < numWorkers; i++ {
+			if loads[i] < loads[min] {
+				min = i
+			}
+		}
+		workers[min].tasks = append(workers[min].tasks, t)
+		loads[min] += stats.estimate(t)
+	}
+
+	return &Scheduler{stats: stats, workers: workers}
+}
+
+// Next returns the next task for worker i to run: first from its own deque,
+// falling back to stealing from the back of another worker's deque. The
+// second return value is false once no work remains anywhere.
+func (s *Scheduler) Next(i int) (Task, bool) {
+	if t, ok := s.workers[i].popFront(); ok {
+		return t, true
+	}
+
+	for j := range s.workers {
+		if j == i {
+			continue
+		}
+		if t, ok := s.workers[j].stealBack(); ok {
+			return t, true
+		}
+	}
+
+	return Task{}, false
+}
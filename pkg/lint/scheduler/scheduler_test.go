@@ -0,0 +1,117 @@
+package scheduler
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newStatsWithDurations(durations map[string]time.Duration) *Stats {
+	return &Stats{Durations: durations}
+}
+
+func TestNewPacksByLongestProcessingTimeFirst(t *testing.T) {
+	tasks := []Task{
+		{LinterName: "slow"},
+		{LinterName: "medium"},
+		{LinterName: "fast1"},
+		{LinterName: "fast2"},
+	}
+	stats := newStatsWithDurations(map[string]time.Duration{
+		"slow":   10 * time.Second,
+		"medium": 4 * time.Second,
+		"fast1":  1 * time.Second,
+		"fast2":  1 * time.Second,
+	})
+
+	sched := New(tasks, 2, stats)
+
+	// Greedy LPT assigns "slow" (10s) to whichever worker is least loaded
+	// first, i.e. worker 0, then packs every remaining task onto worker 1
+	// since it stays lighter than worker 0 at each step: medium (4s), then
+	// fast1 (1s, worker 1 still lighter at 5s vs 10s), then fast2 (1s).
+	// "slow" should end up alone on its worker instead of sharing with
+	// anything else.
+	var slowWorker, otherWorker []string
+	for _, w := range sched.workers {
+		var names []string
+		hasSlow := false
+		for _, task := range w.tasks {
+			names = append(names, task.LinterName)
+			if task.LinterName == "slow" {
+				hasSlow = true
+			}
+		}
+		if hasSlow {
+			slowWorker = names
+		} else {
+			otherWorker = names
+		}
+	}
+
+	if len(slowWorker) != 1 {
+		t.Fatalf("expected \"slow\" to be the only task on its worker, got tasks sharing its worker: %v", slowWorker)
+	}
+	if len(otherWorker) != 3 {
+		t.Fatalf("expected the 3 lighter tasks to be balanced onto the other worker, got %v", otherWorker)
+	}
+}
+
+func TestNextStealsFromAnotherWorkerWhenLocalDequeEmpty(t *testing.T) {
+	tasks := []Task{{LinterName: "only-task"}}
+	stats := newStatsWithDurations(nil)
+
+	sched := New(tasks, 2, stats)
+
+	// All work landed on worker 0 since there's only one task; worker 1
+	// should steal it instead of returning (Task{}, false) immediately.
+	task, ok := sched.Next(1)
+	if !ok {
+		t.Fatal("expected worker 1 to steal the only task")
+	}
+	if task.LinterName != "only-task" {
+		t.Fatalf("stole unexpected task: %+v", task)
+	}
+
+	if _, ok := sched.Next(0); ok {
+		t.Fatal("expected no work left for worker 0 after it was stolen")
+	}
+}
+
+func TestNextReturnsFalseWhenAllDequesEmpty(t *testing.T) {
+	sched := New(nil, 3, newStatsWithDurations(nil))
+
+	for i := 0; i < 3; i++ {
+		if _, ok := sched.Next(i); ok {
+			t.Fatalf("worker %d unexpectedly got a task from an empty scheduler", i)
+		}
+	}
+}
+
+func TestStatsRecordAndSaveRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+	stats := LoadStats(path)
+
+	stats.Record(Task{LinterName: "govet"}, 2*time.Second)
+	stats.Record(Task{LinterName: "gosec", Package: "pkg/foo"}, 5*time.Second)
+
+	if err := stats.Save(); err != nil {
+		t.Fatalf("Save: %s", err)
+	}
+
+	reloaded := LoadStats(path)
+	if d := reloaded.estimate(Task{LinterName: "govet"}); d != 2*time.Second {
+		t.Errorf("govet duration = %s, want 2s", d)
+	}
+	if d := reloaded.estimate(Task{LinterName: "gosec", Package: "pkg/foo"}); d != 5*time.Second {
+		t.Errorf("gosec@pkg/foo duration = %s, want 5s", d)
+	}
+}
+
+func TestLoadStatsMissingFileReturnsEmpty(t *testing.T) {
+	stats := LoadStats(filepath.Join(t.TempDir(), "does-not-exist.json"))
+
+	if d := stats.estimate(Task{LinterName: "anything"}); d != time.Second {
+		t.Errorf("expected default 1s estimate for unknown task, got %s", d)
+	}
+}
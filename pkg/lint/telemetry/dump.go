@@ -0,0 +1,109 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+// WatchSignals installs a handler that dumps r's current state to stderr on
+// SIGUSR1, similar to Go's own runtime stack dump. It returns a stop func
+// that must be called to release the signal handler once the run's linters
+// have finished.
+//
+// SIGQUIT is deliberately not registered here: Go's default SIGQUIT handler
+// prints every goroutine's stack and aborts the process, which is still the
+// only way to force-abort a hung run. Notifying on it here would suppress
+// that default behavior without replacing it.
+func WatchSignals(r *Registry) (stop func()) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGUSR1)
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-sigCh:
+				r.DumpText(os.Stderr)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigCh)
+		close(done)
+	}
+}
+
+// LogPeriodically writes a DumpText-style summary to log every interval
+// until ctx is done; wired up when --debug workers is passed.
+func LogPeriodically(ctx context.Context, r *Registry, log logutils.Log, interval func() <-chan struct{}) {
+	tick := interval()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+			for _, info := range r.Snapshot() {
+				log.Infof("worker: %s on %s stage=%s running=%s", info.LinterName, info.Package, info.Stage,
+					time.Since(info.StartedAt))
+			}
+		}
+	}
+}
+
+type jsonInfo struct {
+	LinterName string `json:"linterName"`
+	Package    string `json:"package"`
+	Stage      string `json:"stage"`
+	StartedAt  string `json:"startedAt"`
+	LastBeat   string `json:"lastHeartbeat"`
+}
+
+// Handler serves the registry's current state as JSON ("/") or as an HTML
+// table ("/html"), wired up under --debug-addr.
+func Handler(r *Registry) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+		snap := r.Snapshot()
+		out := make([]jsonInfo, 0, len(snap))
+		for _, info := range snap {
+			out = append(out, jsonInfo{
+				LinterName: info.LinterName,
+				Package:    info.Package,
+				Stage:      string(info.Stage),
+				StartedAt:  info.StartedAt.Format(httpTimeFormat),
+				LastBeat:   info.LastHeartbeat.Format(httpTimeFormat),
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(out)
+	})
+
+	mux.HandleFunc("/html", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, "<html><body><table border=\"1\">")
+		fmt.Fprint(w, "<tr><th>Linter</th><th>Package</th><th>Stage</th><th>Started</th><th>Last heartbeat</th></tr>")
+		for _, info := range r.Snapshot() {
+			fmt.Fprintf(w, "<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>",
+				info.LinterName, info.Package, info.Stage,
+				info.StartedAt.Format(httpTimeFormat), info.LastHeartbeat.Format(httpTimeFormat))
+		}
+		fmt.Fprint(w, "</table></body></html>")
+	})
+
+	return mux
+}
+
+const httpTimeFormat = "15:04:05.000"
@@ -0,0 +1,168 @@
+// Package telemetry tracks in-flight linter invocations so that a stuck or
+// panicking run can be diagnosed: which linter, which package, how long it
+// had been running, and when it last reported progress.
+package telemetry
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Stage is a coarse phase within a single linter invocation.
+type Stage string
+
+const (
+	StageLoading        Stage = "loading"
+	StageAnalyzing      Stage = "analyzing"
+	StagePostProcessing Stage = "post-processing"
+)
+
+// LinterInfo is a point-in-time snapshot of one in-flight linter invocation.
+type LinterInfo struct {
+	LinterName    string
+	Package       string
+	Stage         Stage
+	GoroutineID   int64
+	StartedAt     time.Time
+	LastHeartbeat time.Time
+}
+
+// Registry tracks every currently-running linter invocation across all
+// workers. It's safe for concurrent use.
+type Registry struct {
+	mu    sync.Mutex
+	infos map[*Handle]*LinterInfo
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{infos: map[*Handle]*LinterInfo{}}
+}
+
+// Handle tracks a single in-flight invocation; callers use it to update
+// stage/heartbeat and must call Done when the invocation finishes.
+type Handle struct {
+	reg *Registry
+}
+
+// Start registers a new in-flight invocation and returns a Handle used to
+// update its state as it progresses.
+func (r *Registry) Start(linterName, pkg string) *Handle {
+	h := &Handle{reg: r}
+	now := time.Now()
+
+	r.mu.Lock()
+	r.infos[h] = &LinterInfo{
+		LinterName:    linterName,
+		Package:       pkg,
+		Stage:         StageLoading,
+		GoroutineID:   currentGoroutineID(),
+		StartedAt:     now,
+		LastHeartbeat: now,
+	}
+	r.mu.Unlock()
+
+	return h
+}
+
+// SetStage records the current coarse stage of the invocation.
+func (h *Handle) SetStage(stage Stage) {
+	h.reg.mu.Lock()
+	defer h.reg.mu.Unlock()
+
+	if info, ok := h.reg.infos[h]; ok {
+		info.Stage = stage
+		info.LastHeartbeat = time.Now()
+	}
+}
+
+// Heartbeat refreshes the last-seen-alive timestamp without changing stage.
+func (h *Handle) Heartbeat() {
+	h.reg.mu.Lock()
+	defer h.reg.mu.Unlock()
+
+	if info, ok := h.reg.infos[h]; ok {
+		info.LastHeartbeat = time.Now()
+	}
+}
+
+// Snapshot returns the current state of h, for inclusion in panic logs.
+func (h *Handle) Snapshot() LinterInfo {
+	h.reg.mu.Lock()
+	defer h.reg.mu.Unlock()
+
+	if info, ok := h.reg.infos[h]; ok {
+		return *info
+	}
+
+	return LinterInfo{}
+}
+
+// Done unregisters the invocation; it must be called exactly once, usually
+// via defer right after Start.
+func (h *Handle) Done() {
+	h.reg.mu.Lock()
+	defer h.reg.mu.Unlock()
+
+	delete(h.reg.infos, h)
+}
+
+// Snapshot returns the state of every currently in-flight invocation,
+// sorted by how long they've been running (longest first).
+func (r *Registry) Snapshot() []LinterInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ret := make([]LinterInfo, 0, len(r.infos))
+	for _, info := range r.infos {
+		ret = append(ret, *info)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].StartedAt.Before(ret[j].StartedAt)
+	})
+
+	return ret
+}
+
+// DumpText writes a pprof-stack-dump-style textual report of every
+// in-flight invocation to w.
+func (r *Registry) DumpText(w io.Writer) {
+	snap := r.Snapshot()
+
+	fmt.Fprintf(w, "golangci-lint worker dump: %d linter(s) in flight\n", len(snap))
+	now := time.Now()
+	for _, info := range snap {
+		fmt.Fprintf(w, "* %s on %s: stage=%s running=%s idle=%s goroutine=%d\n",
+			info.LinterName, info.Package, info.Stage,
+			now.Sub(info.StartedAt).Round(time.Millisecond),
+			now.Sub(info.LastHeartbeat).Round(time.Millisecond),
+			info.GoroutineID)
+	}
+}
+
+// currentGoroutineID parses the calling goroutine's id out of a stack
+// trace, mirroring the common "goroutine N [running]:" trick since the
+// runtime doesn't expose one directly.
+func currentGoroutineID() int64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+
+	fields := bytes.Fields(buf)
+	if len(fields) < 2 {
+		return 0
+	}
+
+	id, err := strconv.ParseInt(string(fields[1]), 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return id
+}
@@ -0,0 +1,67 @@
+package telemetry
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStartSnapshotDone(t *testing.T) {
+	reg := NewRegistry()
+
+	h := reg.Start("gosec", "pkg/foo")
+	if got := len(reg.Snapshot()); got != 1 {
+		t.Fatalf("expected 1 in-flight invocation after Start, got %d", got)
+	}
+
+	info := h.Snapshot()
+	if info.LinterName != "gosec" || info.Package != "pkg/foo" {
+		t.Fatalf("unexpected snapshot: %+v", info)
+	}
+	if info.Stage != StageLoading {
+		t.Fatalf("expected initial stage %q, got %q", StageLoading, info.Stage)
+	}
+
+	h.SetStage(StageAnalyzing)
+	if got := h.Snapshot().Stage; got != StageAnalyzing {
+		t.Fatalf("expected stage %q after SetStage, got %q", StageAnalyzing, got)
+	}
+
+	h.Done()
+	if got := len(reg.Snapshot()); got != 0 {
+		t.Fatalf("expected 0 in-flight invocations after Done, got %d", got)
+	}
+	if info := h.Snapshot(); info.LinterName != "" {
+		t.Fatalf("expected zero-value snapshot for a Done handle, got %+v", info)
+	}
+}
+
+func TestSnapshotOrderedByStartTime(t *testing.T) {
+	reg := NewRegistry()
+
+	h1 := reg.Start("first", "pkg/a")
+	h2 := reg.Start("second", "pkg/b")
+	defer h1.Done()
+	defer h2.Done()
+
+	snap := reg.Snapshot()
+	if len(snap) != 2 {
+		t.Fatalf("expected 2 in-flight invocations, got %d", len(snap))
+	}
+	if snap[0].LinterName != "first" || snap[1].LinterName != "second" {
+		t.Fatalf("expected snapshot ordered by start time, got %+v", snap)
+	}
+}
+
+func TestDumpTextIncludesInFlightInvocations(t *testing.T) {
+	reg := NewRegistry()
+	h := reg.Start("unused", "pkg/bar")
+	defer h.Done()
+
+	var buf strings.Builder
+	reg.DumpText(&buf)
+
+	out := buf.String()
+	if !strings.Contains(out, "unused") || !strings.Contains(out, "pkg/bar") {
+		t.Fatalf("expected dump to mention linter and package, got: %s", out)
+	}
+}
@@ -0,0 +1,136 @@
+// Package workerpool provides a reusable async worker pool used to run
+// lint tasks with bounded concurrency, per-task timeouts and hashed
+// routing, independent of how tasks are produced.
+package workerpool
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+)
+
+// Task is a single unit of work submitted to a Pool. Key is used for hashed
+// routing: tasks sharing a Key are always run on the same worker, which
+// keeps worker-local state (e.g. per-linter caches) warm across tasks.
+type Task struct {
+	Key string
+	Run func(ctx context.Context) (interface{}, error)
+}
+
+// Pool runs submitted tasks with bounded concurrency.
+type Pool interface {
+	// Submit enqueues t, blocking if the pool is already at its in-flight
+	// limit (backpressure).
+	Submit(t Task)
+	// Shutdown stops accepting new tasks, waits for in-flight tasks to
+	// finish (or ctx to expire), and releases pool resources.
+	Shutdown(ctx context.Context) error
+}
+
+// Result is delivered to an AsyncPool's completion callback once a task
+// finishes or times out. Tasks still queued at Shutdown are run and
+// reported like any other.
+type Result struct {
+	Task  Task
+	Value interface{}
+	Err   error
+}
+
+// AsyncPool is a Pool whose results are delivered asynchronously via a
+// completion callback rather than a blocking return value, so callers can
+// keep submitting work while earlier tasks are still running.
+type AsyncPool interface {
+	Pool
+	// OnComplete registers the callback invoked for every finished task.
+	// It must be called before the first Submit.
+	OnComplete(cb func(Result))
+}
+
+type workerPool struct {
+	workers []chan Task
+	onDone  func(Result)
+	wg      sync.WaitGroup
+}
+
+// New creates an AsyncPool with the given number of workers. newTaskCtx is
+// called once per task to derive the context it runs under (e.g. applying
+// run.linter-deadline); pass nil to run tasks under context.Background.
+func New(numWorkers int, newTaskCtx func() (context.Context, context.CancelFunc)) AsyncPool {
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+	if newTaskCtx == nil {
+		newTaskCtx = func() (context.Context, context.CancelFunc) {
+			return context.WithCancel(context.Background())
+		}
+	}
+
+	p := &workerPool{
+		workers: make([]chan Task, numWorkers),
+	}
+
+	for i := range p.workers {
+		// A small buffer gives each worker a short queue, so Submit only
+		// blocks (backpressure) once the worker its task routes to is
+		// already backed up, even if other workers are idle.
+		p.workers[i] = make(chan Task, 4)
+		p.wg.Add(1)
+		go p.runWorker(p.workers[i], newTaskCtx)
+	}
+
+	return p
+}
+
+func (p *workerPool) runWorker(tasks <-chan Task, newTaskCtx func() (context.Context, context.CancelFunc)) {
+	defer p.wg.Done()
+
+	for t := range tasks {
+		ctx, cancel := newTaskCtx()
+		value, err := t.Run(ctx)
+		cancel()
+
+		if p.onDone != nil {
+			p.onDone(Result{Task: t, Value: value, Err: err})
+		}
+	}
+}
+
+func (p *workerPool) OnComplete(cb func(Result)) {
+	p.onDone = cb
+}
+
+func (p *workerPool) route(key string) int {
+	if key == "" || len(p.workers) == 1 {
+		return 0
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32()) % len(p.workers)
+}
+
+func (p *workerPool) Submit(t Task) {
+	p.workers[p.route(t.Key)] <- t
+}
+
+// Shutdown closes every worker's input channel so each runWorker exits once
+// it has drained (and reported via onDone) any tasks still queued, then
+// waits for all workers to finish or ctx to expire.
+func (p *workerPool) Shutdown(ctx context.Context) error {
+	for _, w := range p.workers {
+		close(w)
+	}
+
+	waitCh := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(waitCh)
+	}()
+
+	select {
+	case <-waitCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
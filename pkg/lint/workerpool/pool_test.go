@@ -0,0 +1,148 @@
+package workerpool
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSubmitRunsAllTasksAndDeliversResults(t *testing.T) {
+	pool := New(4, nil)
+
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	var wg sync.WaitGroup
+	wg.Add(10)
+
+	pool.OnComplete(func(res Result) {
+		mu.Lock()
+		seen[res.Task.Key] = true
+		mu.Unlock()
+		wg.Done()
+	})
+
+	for i := 0; i < 10; i++ {
+		key := string(rune('a' + i))
+		pool.Submit(Task{
+			Key: key,
+			Run: func(ctx context.Context) (interface{}, error) {
+				return key, nil
+			},
+		})
+	}
+
+	waitOrTimeout(t, &wg, time.Second)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(seen) != 10 {
+		t.Fatalf("expected 10 distinct completions, got %d", len(seen))
+	}
+}
+
+func TestRouteIsStableForSameKey(t *testing.T) {
+	pool := New(8, nil).(*workerPool)
+
+	for _, key := range []string{"gosec", "unused", "govet", ""} {
+		first := pool.route(key)
+		for i := 0; i < 5; i++ {
+			if got := pool.route(key); got != first {
+				t.Fatalf("route(%q) not stable: got %d and %d", key, first, got)
+			}
+		}
+	}
+}
+
+func TestTaskTimeoutSurfacesDeadlineExceeded(t *testing.T) {
+	pool := New(1, func() (context.Context, context.CancelFunc) {
+		return context.WithTimeout(context.Background(), 10*time.Millisecond)
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	var gotErr error
+	pool.OnComplete(func(res Result) {
+		gotErr = res.Err
+		wg.Done()
+	})
+
+	pool.Submit(Task{
+		Key: "slow",
+		Run: func(ctx context.Context) (interface{}, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	})
+
+	waitOrTimeout(t, &wg, time.Second)
+
+	if gotErr != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", gotErr)
+	}
+}
+
+func TestShutdownWaitsForInFlightTasksToReport(t *testing.T) {
+	pool := New(2, nil)
+
+	var mu sync.Mutex
+	completed := 0
+	pool.OnComplete(func(res Result) {
+		mu.Lock()
+		completed++
+		mu.Unlock()
+	})
+
+	for i := 0; i < 5; i++ {
+		pool.Submit(Task{Run: func(ctx context.Context) (interface{}, error) {
+			return nil, nil
+		}})
+	}
+
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %s", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if completed != 5 {
+		t.Fatalf("expected all 5 tasks to report completion before Shutdown returned, got %d", completed)
+	}
+}
+
+func TestShutdownReturnsCtxErrOnExpiry(t *testing.T) {
+	pool := New(1, nil)
+
+	block := make(chan struct{})
+	pool.Submit(Task{Run: func(ctx context.Context) (interface{}, error) {
+		<-block
+		return nil, nil
+	}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := pool.Shutdown(ctx)
+	close(block)
+
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func waitOrTimeout(t *testing.T, wg *sync.WaitGroup, d time.Duration) {
+	t.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(d):
+		t.Fatal("timed out waiting for completions")
+	}
+}
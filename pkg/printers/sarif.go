@@ -0,0 +1,201 @@
+package printers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const sarifVersion = "2.1.0"
+const sarifSchema = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+const sarifToolInformationURI = "https://golangci-lint.run"
+
+// sarifToolVersion is golangci-lint's own version, set via -ldflags at build
+// time the same way the CLI's `--version` output is. It's used as every
+// run's tool.driver.version since individual linters don't carry their own
+// version numbers through result.Issue.
+var sarifToolVersion = "unknown"
+
+// SARIF prints issues as a single SARIF 2.1.0 log, with one run per linter,
+// for ingestion by code-scanning systems (GitHub code scanning, GitLab SAST).
+type SARIF struct {
+	w io.Writer
+}
+
+func NewSARIF(w io.Writer) *SARIF {
+	return &SARIF{w: w}
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID      string `json:"id"`
+	HelpURI string `json:"helpUri,omitempty"`
+}
+
+type sarifDescription struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string             `json:"ruleId"`
+	Level               string             `json:"level"`
+	Message             sarifDescription   `json:"message"`
+	Locations           []sarifLocation    `json:"locations"`
+	PartialFingerprints map[string]string  `json:"partialFingerprints,omitempty"`
+	Suppressions        []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+// sarifSuppression records that a result was suppressed in source (e.g. by a
+// //nolint comment) rather than dropped, per the SARIF 2.1.0 spec's
+// suppressions object.
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// severityLevel maps a golangci-lint issue severity to a SARIF result
+// level; unknown or empty severities are reported as "warning" so issues
+// aren't silently dropped from code-scanning views.
+func severityLevel(severity string) string {
+	switch severity {
+	case "error":
+		return "error"
+	case "note", "info":
+		return "note"
+	default:
+		return "warning"
+	}
+}
+
+func ruleID(i *result.Issue) string {
+	if i.RuleID != "" {
+		return i.RuleID
+	}
+
+	return i.FromLinter
+}
+
+// helpURI points each rule at its linter's entry in the golangci-lint docs,
+// which is the closest thing to a per-rule help page golangci-lint has.
+func helpURI(linterName string) string {
+	return fmt.Sprintf("https://golangci-lint.run/usage/linters/#%s", linterName)
+}
+
+func toSarifResult(i *result.Issue) sarifResult {
+	res := sarifResult{
+		RuleID:  ruleID(i),
+		Level:   severityLevel(i.Severity),
+		Message: sarifDescription{Text: i.Text},
+		Locations: []sarifLocation{{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: i.FilePath()},
+				Region:           sarifRegion{StartLine: i.Line(), StartColumn: i.Pos.Column},
+			},
+		}},
+	}
+
+	if i.Fingerprint != "" {
+		res.PartialFingerprints = map[string]string{"golangciLintFingerprint": i.Fingerprint}
+	}
+
+	// Suppressed is set by the Nolint processor for issues matched by a
+	// //nolint comment: instead of dropping them, it tags them so sinks
+	// that understand suppressions (like SARIF) can still report them.
+	if i.Suppressed {
+		res.Suppressions = []sarifSuppression{{Kind: "inSource", Justification: i.SuppressComment}}
+	}
+
+	return res
+}
+
+// Print consumes issues (typically the channel returned by Runner.Run),
+// grouping them into one SARIF run per linter, and writes a single SARIF
+// log to p.w.
+//
+// Issues with Suppressed set (tagged, not dropped, by the Nolint processor)
+// are reported as results carrying a SARIF "suppressions" entry instead of
+// being omitted, so //nolint'd findings stay visible to code-scanning UIs
+// that understand suppressions.
+func (p *SARIF) Print(ctx context.Context, issues <-chan result.Issue) error {
+	runsByLinter := map[string]*sarifRun{}
+	rulesByLinter := map[string]map[string]bool{}
+	var order []string
+
+	for i := range issues {
+		i := i
+		run, ok := runsByLinter[i.FromLinter]
+		if !ok {
+			run = &sarifRun{Tool: sarifTool{Driver: sarifDriver{
+				Name:           i.FromLinter,
+				Version:        sarifToolVersion,
+				InformationURI: sarifToolInformationURI,
+			}}}
+			runsByLinter[i.FromLinter] = run
+			rulesByLinter[i.FromLinter] = map[string]bool{}
+			order = append(order, i.FromLinter)
+		}
+
+		run.Results = append(run.Results, toSarifResult(&i))
+
+		rid := ruleID(&i)
+		if !rulesByLinter[i.FromLinter][rid] {
+			rulesByLinter[i.FromLinter][rid] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: rid, HelpURI: helpURI(i.FromLinter)})
+		}
+	}
+
+	log := sarifLog{Schema: sarifSchema, Version: sarifVersion}
+	for _, linterName := range order {
+		log.Runs = append(log.Runs, *runsByLinter[linterName])
+	}
+
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(log); err != nil {
+		return fmt.Errorf("failed to encode SARIF output: %s", err)
+	}
+
+	return nil
+}
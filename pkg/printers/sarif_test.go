@@ -0,0 +1,122 @@
+package printers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"go/token"
+	"testing"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestSeverityLevel(t *testing.T) {
+	cases := map[string]string{
+		"error":   "error",
+		"note":    "note",
+		"info":    "note",
+		"warning": "warning",
+		"":        "warning",
+		"bogus":   "warning",
+	}
+
+	for severity, want := range cases {
+		if got := severityLevel(severity); got != want {
+			t.Errorf("severityLevel(%q) = %q, want %q", severity, got, want)
+		}
+	}
+}
+
+func TestHelpURIIncludesLinterName(t *testing.T) {
+	got := helpURI("gosec")
+	want := "https://golangci-lint.run/usage/linters/#gosec"
+	if got != want {
+		t.Errorf("helpURI(%q) = %q, want %q", "gosec", got, want)
+	}
+}
+
+func TestPrintEmitsOneRunPerLinterWithLocationsAndFingerprints(t *testing.T) {
+	issues := []result.Issue{
+		{
+			FromLinter:  "govet",
+			RuleID:      "shadow",
+			Text:        "shadowed variable",
+			Severity:    "warning",
+			Fingerprint: "abc123",
+			Pos:         token.Position{Filename: "foo.go", Line: 10, Column: 5},
+		},
+		{
+			FromLinter: "gosec",
+			RuleID:     "G101",
+			Text:       "possible hardcoded credential",
+			Severity:   "error",
+			Pos:        token.Position{Filename: "bar.go", Line: 20, Column: 1},
+		},
+		{
+			FromLinter:      "gosec",
+			RuleID:          "G101",
+			Text:            "possible hardcoded credential",
+			Severity:        "error",
+			Suppressed:      true,
+			SuppressComment: "nolint:gosec",
+			Pos:             token.Position{Filename: "baz.go", Line: 1, Column: 1},
+		},
+	}
+
+	ch := make(chan result.Issue, len(issues))
+	for _, i := range issues {
+		ch <- i
+	}
+	close(ch)
+
+	var buf bytes.Buffer
+	if err := NewSARIF(&buf).Print(context.Background(), ch); err != nil {
+		t.Fatalf("Print: %s", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("Print produced invalid JSON: %s", err)
+	}
+
+	if len(log.Runs) != 2 {
+		t.Fatalf("expected one run per linter, got %d runs", len(log.Runs))
+	}
+
+	govetRun, gosecRun := log.Runs[0], log.Runs[1]
+	if govetRun.Tool.Driver.Name != "govet" || gosecRun.Tool.Driver.Name != "gosec" {
+		t.Fatalf("unexpected run order/names: %+v", []string{govetRun.Tool.Driver.Name, gosecRun.Tool.Driver.Name})
+	}
+
+	if len(govetRun.Results) != 1 {
+		t.Fatalf("expected 1 govet result, got %d", len(govetRun.Results))
+	}
+	res := govetRun.Results[0]
+	if res.Locations[0].PhysicalLocation.ArtifactLocation.URI != "foo.go" {
+		t.Errorf("artifact URI = %q, want foo.go", res.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	}
+	if res.Locations[0].PhysicalLocation.Region.StartLine != 10 || res.Locations[0].PhysicalLocation.Region.StartColumn != 5 {
+		t.Errorf("region = %+v, want line 10 col 5", res.Locations[0].PhysicalLocation.Region)
+	}
+	if res.PartialFingerprints["golangciLintFingerprint"] != "abc123" {
+		t.Errorf("fingerprint = %+v, want abc123", res.PartialFingerprints)
+	}
+
+	if len(gosecRun.Results) != 2 {
+		t.Fatalf("expected 2 gosec results, got %d", len(gosecRun.Results))
+	}
+	plain, suppressed := gosecRun.Results[0], gosecRun.Results[1]
+	if len(plain.Suppressions) != 0 {
+		t.Errorf("expected non-suppressed result to carry no suppressions, got %+v", plain.Suppressions)
+	}
+	if len(suppressed.Suppressions) != 1 || suppressed.Suppressions[0].Kind != "inSource" {
+		t.Fatalf("expected suppressed result to carry an inSource suppression, got %+v", suppressed.Suppressions)
+	}
+	if suppressed.Suppressions[0].Justification != "nolint:gosec" {
+		t.Errorf("suppression justification = %q, want %q", suppressed.Suppressions[0].Justification, "nolint:gosec")
+	}
+
+	if len(gosecRun.Tool.Driver.Rules) != 1 {
+		t.Fatalf("expected gosec's two results to dedupe onto 1 rule, got %d", len(gosecRun.Tool.Driver.Rules))
+	}
+}